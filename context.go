@@ -0,0 +1,72 @@
+package logger
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+type contextKey int
+
+const (
+	loggerContextKey contextKey = iota
+	requestIDContextKey
+)
+
+// NewContext returns a copy of the parent context carrying specified logger.
+func NewContext(ctx context.Context, l Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey, l)
+}
+
+// FromContext returns logger stored in the context. If there is no logger in
+// the context, it returns the standard logger.
+func FromContext(ctx context.Context) Logger {
+	if l, ok := ctx.Value(loggerContextKey).(Logger); ok {
+		return l
+	}
+	return standardLogger
+}
+
+// ContextWithRequestID returns a copy of the parent context carrying specified
+// request ID.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey, id)
+}
+
+// RequestIDFromContext returns request ID stored in the context.
+func RequestIDFromContext(ctx context.Context) (id string, ok bool) {
+	id, ok = ctx.Value(requestIDContextKey).(string)
+	return id, ok
+}
+
+// RequestLogger prepares context for request-scoped logging. It generates a
+// request ID unless the context already carries one, and tags the logger
+// returned by FromContext with it. Both the request ID and the tagged logger
+// are stored in the returned context, so subsequent FromContext calls return
+// the tagged logger.
+func RequestLogger(ctx context.Context) (context.Context, Logger) {
+	id, ok := RequestIDFromContext(ctx)
+	if !ok || id == "" {
+		id = generateRequestID()
+		ctx = ContextWithRequestID(ctx, id)
+	}
+
+	l := FromContext(ctx)
+	tags := l.Tags()
+	for _, tag := range tags {
+		if tag == id {
+			return NewContext(ctx, l), l
+		}
+	}
+	l = l.WithTags(append(tags, id)...)
+
+	return NewContext(ctx, l), l
+}
+
+func generateRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err) // Failing random number generator is not recoverable
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,80 @@
+package logger_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	log "github.com/g2a-com/klio-logger-go/v2"
+)
+
+func Example_requestLogger() {
+	ctx, l := log.RequestLogger(context.Background())
+	l.Print("request started") // Klio: [INFO][<REQUEST ID>] request started
+
+	log.FromContext(ctx).Print("request finished") // Klio: [INFO][<REQUEST ID>] request finished
+}
+
+func TestFromContext(t *testing.T) {
+	t.Run("return standard logger if context has no logger", func(t *testing.T) {
+		assert.Equal(t, log.StandardLogger(), log.FromContext(context.Background()))
+	})
+
+	t.Run("return logger stored in context", func(t *testing.T) {
+		var b bytes.Buffer
+		l := log.New(&b)
+		assert.Equal(t, l, log.FromContext(log.NewContext(context.Background(), l)))
+	})
+}
+
+func TestRequestIDFromContext(t *testing.T) {
+	_, ok := log.RequestIDFromContext(context.Background())
+	assert.False(t, ok)
+
+	id, ok := log.RequestIDFromContext(log.ContextWithRequestID(context.Background(), "foo"))
+	assert.True(t, ok)
+	assert.Equal(t, "foo", id)
+}
+
+func TestRequestLogger(t *testing.T) {
+	t.Run("generate request ID if absent", func(t *testing.T) {
+		ctx1, l1 := log.RequestLogger(context.Background())
+		ctx2, l2 := log.RequestLogger(context.Background())
+
+		id1, ok := log.RequestIDFromContext(ctx1)
+		assert.True(t, ok)
+		assert.Regexp(t, "^[0-9a-f]{32}$", id1)
+		assert.Equal(t, []string{id1}, l1.Tags())
+
+		id2, ok := log.RequestIDFromContext(ctx2)
+		assert.True(t, ok)
+		assert.NotEqual(t, id1, id2)
+		assert.Equal(t, []string{id2}, l2.Tags())
+	})
+
+	t.Run("reuse existing request ID", func(t *testing.T) {
+		ctx, l := log.RequestLogger(log.ContextWithRequestID(context.Background(), "foo"))
+
+		id, _ := log.RequestIDFromContext(ctx)
+		assert.Equal(t, "foo", id)
+		assert.Equal(t, []string{"foo"}, l.Tags())
+
+		_, l = log.RequestLogger(ctx)
+		assert.Equal(t, []string{"foo"}, l.Tags())
+	})
+
+	t.Run("propagate tagged logger through context", func(t *testing.T) {
+		var b bytes.Buffer
+		ctx := log.NewContext(context.Background(), log.New(&b).WithTags("a"))
+		ctx = log.ContextWithRequestID(ctx, "foo")
+
+		ctx, l := log.RequestLogger(ctx)
+		log.FromContext(ctx).Print("bar")
+
+		assert.Equal(t, l, log.FromContext(ctx))
+		assert.Equal(t, []string{"a", "foo"}, log.FromContext(ctx).Tags())
+		assert.Equal(t, "\033_klio_mode \"line\"\033\\\033_klio_log_level \"info\"\033\\\033_klio_tags [\"a\",\"foo\"]\033\\bar\033_klio_reset\033\\\n", b.String())
+	})
+}
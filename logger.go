@@ -144,20 +144,24 @@ func New(output io.Writer) Logger {
 }
 
 func (l *logger) updateLinePrefix() {
-	level, err := json.Marshal(l.level)
+	l.linePrefix = klioLinePrefix(l.mode, l.level, l.tags)
+}
+
+func klioLinePrefix(mode Mode, level Level, tags []string) string {
+	levelJSON, err := json.Marshal(level)
 	if err != nil {
-		level = []byte("\"" + DefaultLevel + "\"")
+		levelJSON = []byte("\"" + DefaultLevel + "\"")
 	}
-	mode, err := json.Marshal(l.mode)
+	modeJSON, err := json.Marshal(mode)
 	if err != nil {
-		mode = []byte("\"" + DefaultMode + "\"")
+		modeJSON = []byte("\"" + DefaultMode + "\"")
 	}
-	tags, err := json.Marshal(l.tags)
-	if err != nil || string(tags) == "null" {
-		tags = []byte("[]")
+	tagsJSON, err := json.Marshal(tags)
+	if err != nil || string(tagsJSON) == "null" {
+		tagsJSON = []byte("[]")
 	}
-	l.linePrefix = fmt.Sprintf(
-		"\033_klio_mode %s\033\\\033_klio_log_level %s\033\\\033_klio_tags %s\033\\", mode, level, tags,
+	return fmt.Sprintf(
+		"\033_klio_mode %s\033\\\033_klio_log_level %s\033\\\033_klio_tags %s\033\\", modeJSON, levelJSON, tagsJSON,
 	)
 }
 
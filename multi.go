@@ -0,0 +1,291 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Format type.
+type Format string
+
+const (
+	// KlioFormat decorates lines with control sequences interpreted by Klio.
+	KlioFormat Format = "klio"
+	// HumanFormat renders lines as plain text, prefixed with level and tags in
+	// the same way Klio displays them.
+	HumanFormat Format = "human"
+	// JSONFormat renders each line as a single JSON object.
+	JSONFormat Format = "json"
+	// DefaultFormat is an alias for klio format.
+	DefaultFormat = KlioFormat
+)
+
+var levelsSeverity = map[Level]int{
+	FatalLevel:   0,
+	ErrorLevel:   1,
+	WarnLevel:    2,
+	InfoLevel:    3,
+	VerboseLevel: 4,
+	DebugLevel:   5,
+	SpamLevel:    6,
+}
+
+// Sink describes a single output of a MultiLogger.
+type Sink struct {
+	// Output is a writer used to print logs. Sinks without output are ignored.
+	Output io.Writer
+	// Format used to render lines. Empty value means DefaultFormat, unknown
+	// formats fall back to DefaultFormat as well.
+	Format Format
+	// Mode used to render lines. Empty value means mode of the logger.
+	Mode Mode
+	// Level is the least severe level written to the sink. Empty value means
+	// that all lines are written.
+	Level Level
+}
+
+// MultiError aggregates errors returned by sinks of a MultiLogger, in the
+// order in which they occurred.
+type MultiError []error
+
+func (e MultiError) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Is reports whether any of aggregated errors matches target, so errors.Is
+// can find errors returned by sinks.
+func (e MultiError) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first of aggregated errors matching target, so errors.As can
+// find errors returned by sinks.
+func (e MultiError) As(target interface{}) bool {
+	for _, err := range e {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Unwrap returns aggregated errors.
+func (e MultiError) Unwrap() []error {
+	return e
+}
+
+// MultiLogger is a Logger writing each line to multiple sinks. Every sink
+// renders the line using its own format, mode and level threshold. Writer
+// returned by Output passes bytes to all sinks as they are, without rendering.
+type MultiLogger interface {
+	Logger
+	// Sinks returns sinks used by a logger.
+	Sinks() []Sink
+	// WithSink creates new logger instance writing to an additional sink. It
+	// doesn't change existing logger instance.
+	WithSink(Sink) MultiLogger
+}
+
+type multiLogger struct {
+	sinks []Sink
+	tags  []string
+	level Level
+	mode  Mode
+}
+
+// NewMulti creates new instance of the MultiLogger. Lines are written to
+// sinks in the order in which they were specified.
+func NewMulti(sinks ...Sink) MultiLogger {
+	return &multiLogger{
+		sinks: appendSinks([]Sink{}, sinks...),
+		tags:  []string{},
+		level: DefaultLevel,
+		mode:  DefaultMode,
+	}
+}
+
+func (l *multiLogger) Sinks() []Sink {
+	r := make([]Sink, len(l.sinks))
+	copy(r, l.sinks)
+	return r
+}
+
+func (l *multiLogger) WithSink(sink Sink) MultiLogger {
+	n := *l
+	n.sinks = appendSinks(l.Sinks(), sink)
+	return &n
+}
+
+func (l *multiLogger) Tags() []string {
+	r := make([]string, len(l.tags))
+	copy(r, l.tags)
+	return r
+}
+
+func (l *multiLogger) Level() Level {
+	return l.level
+}
+
+func (l *multiLogger) Mode() Mode {
+	return l.mode
+}
+
+// Output returns writer passing bytes to outputs of all sinks, without
+// rendering them and regardless of sinks levels.
+func (l *multiLogger) Output() io.Writer {
+	return sinksWriter(l.Sinks())
+}
+
+func (l *multiLogger) WithLevel(level Level) Logger {
+	n := *l
+	n.level = level
+	return &n
+}
+
+func (l *multiLogger) WithMode(mode Mode) Logger {
+	n := *l
+	n.mode = mode
+	return &n
+}
+
+func (l *multiLogger) WithTags(tags ...string) Logger {
+	n := *l
+	n.tags = tags
+	return &n
+}
+
+// WithOutput creates new logger instance with all sinks replaced by a single
+// sink using specified Writer and default settings.
+func (l *multiLogger) WithOutput(output io.Writer) Logger {
+	n := *l
+	n.sinks = appendSinks([]Sink{}, Sink{Output: output})
+	return &n
+}
+
+func (l *multiLogger) Print(v ...interface{}) Logger {
+	l.print(fmt.Sprint(v...))
+	return l
+}
+
+func (l *multiLogger) Printf(format string, v ...interface{}) Logger {
+	return l.Print(fmt.Sprintf(format, v...))
+}
+
+func (l *multiLogger) Write(p []byte) (int, error) {
+	var errs MultiError
+	scanner := bufio.NewScanner(bytes.NewReader(p)) // Scan lines
+	for scanner.Scan() {
+		errs = append(errs, l.print(scanner.Text())...)
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	if len(errs) > 0 {
+		return len(p), errs
+	}
+	return len(p), nil
+}
+
+func (l *multiLogger) print(msg string) MultiError {
+	var errs MultiError
+	for _, sink := range l.sinks {
+		if !sink.accepts(l.level) {
+			continue
+		}
+		mode := sink.Mode
+		if mode == "" {
+			mode = l.mode
+		}
+		if _, err := sink.Output.Write(renderLine(sink.Format, mode, l.level, l.tags, msg)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+func appendSinks(dst []Sink, sinks ...Sink) []Sink {
+	for _, sink := range sinks {
+		if sink.Output != nil {
+			dst = append(dst, sink)
+		}
+	}
+	return dst
+}
+
+type sinksWriter []Sink
+
+func (w sinksWriter) Write(p []byte) (int, error) {
+	var errs MultiError
+	for _, sink := range w {
+		if _, err := sink.Output.Write(p); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return len(p), errs
+	}
+	return len(p), nil
+}
+
+func (s Sink) accepts(level Level) bool {
+	if s.Level == "" {
+		return true
+	}
+	return severity(level) <= severity(s.Level)
+}
+
+func severity(level Level) int {
+	if s, ok := levelsSeverity[level]; ok {
+		return s
+	}
+	return levelsSeverity[DefaultLevel]
+}
+
+func renderLine(format Format, mode Mode, level Level, tags []string, msg string) []byte {
+	switch format {
+	case HumanFormat:
+		if mode == RawMode {
+			return []byte(msg + "\n")
+		}
+		var b strings.Builder
+		b.WriteString("[" + strings.ToUpper(string(level)) + "]")
+		for _, tag := range tags {
+			b.WriteString("[" + strings.ToUpper(tag) + "]")
+		}
+		b.WriteString(" " + msg + "\n")
+		return []byte(b.String())
+	case JSONFormat:
+		if tags == nil {
+			tags = []string{}
+		}
+		line, err := json.Marshal(struct {
+			Level   Level    `json:"level"`
+			Mode    Mode     `json:"mode"`
+			Tags    []string `json:"tags"`
+			Message string   `json:"message"`
+		}{level, mode, tags, msg})
+		if err != nil {
+			return []byte{}
+		}
+		return append(line, '\n')
+	case KlioFormat, "":
+		return []byte(klioLinePrefix(mode, level, tags) + msg + "\033_klio_reset\033\\\n")
+	default:
+		// Unknown formats fall back to DefaultFormat.
+		return renderLine(DefaultFormat, mode, level, tags, msg)
+	}
+}
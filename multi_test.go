@@ -0,0 +1,242 @@
+package logger_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	log "github.com/g2a-com/klio-logger-go/v2"
+)
+
+type failingWriter struct {
+	err error
+}
+
+func (w failingWriter) Write(p []byte) (int, error) {
+	return 0, w.err
+}
+
+type sinkError string
+
+func (e sinkError) Error() string {
+	return string(e)
+}
+
+func Example_multi() {
+	var file bytes.Buffer
+
+	l := log.NewMulti(
+		log.Sink{Output: os.Stdout, Format: log.HumanFormat},                   // Console gets human readable lines
+		log.Sink{Output: &file, Format: log.JSONFormat, Level: log.DebugLevel}, // File gets JSON lines up to debug level
+	)
+
+	l.WithTags("foo").Print("hello world")
+}
+
+func TestNewMulti(t *testing.T) {
+	var b bytes.Buffer
+	l := log.NewMulti(log.Sink{Output: &b})
+	assert.Implements(t, (*log.Logger)(nil), l)
+	assert.Equal(t, []log.Sink{{Output: &b}}, l.Sinks())
+	assert.Equal(t, log.DefaultLevel, l.Level())
+	assert.Equal(t, log.DefaultMode, l.Mode())
+	assert.Equal(t, []string{}, l.Tags())
+}
+
+func TestMultiPrint(t *testing.T) {
+	var klio, human, json bytes.Buffer
+
+	l := log.NewMulti(
+		log.Sink{Output: &klio},
+		log.Sink{Output: &human, Format: log.HumanFormat, Level: log.InfoLevel},
+		log.Sink{Output: &json, Format: log.JSONFormat, Mode: log.RawMode, Level: log.DebugLevel},
+	)
+
+	l.WithTags("a", "b").WithLevel(log.WarnLevel).Print("foo")
+	l.WithLevel(log.DebugLevel).Printf("%s", "bar")
+	l.WithLevel(log.SpamLevel).Print("baz")
+
+	assert.Equal(
+		t,
+		"\033_klio_mode \"line\"\033\\\033_klio_log_level \"warn\"\033\\\033_klio_tags [\"a\",\"b\"]\033\\foo\033_klio_reset\033\\\n"+
+			"\033_klio_mode \"line\"\033\\\033_klio_log_level \"debug\"\033\\\033_klio_tags []\033\\bar\033_klio_reset\033\\\n"+
+			"\033_klio_mode \"line\"\033\\\033_klio_log_level \"spam\"\033\\\033_klio_tags []\033\\baz\033_klio_reset\033\\\n",
+		klio.String(),
+	)
+	assert.Equal(t, "[WARN][A][B] foo\n", human.String())
+	assert.Equal(
+		t,
+		"{\"level\":\"warn\",\"mode\":\"raw\",\"tags\":[\"a\",\"b\"],\"message\":\"foo\"}\n"+
+			"{\"level\":\"debug\",\"mode\":\"raw\",\"tags\":[],\"message\":\"bar\"}\n",
+		json.String(),
+	)
+}
+
+func TestMultiWithMode(t *testing.T) {
+	var b1, b2 bytes.Buffer
+
+	l := log.NewMulti(
+		log.Sink{Output: &b1, Format: log.HumanFormat},
+		log.Sink{Output: &b2, Format: log.HumanFormat, Mode: log.LineMode},
+	)
+	l.WithMode(log.RawMode).Print("foo")
+
+	assert.Equal(t, "foo\n", b1.String())
+	assert.Equal(t, "[INFO] foo\n", b2.String())
+}
+
+func TestMultiWithSink(t *testing.T) {
+	var b1, b2 bytes.Buffer
+
+	l1 := log.NewMulti(log.Sink{Output: &b1, Format: log.HumanFormat})
+	l2 := l1.WithSink(log.Sink{Output: &b2, Format: log.HumanFormat})
+	l1.Print("foo")
+	l2.Print("bar")
+
+	assert.Len(t, l1.Sinks(), 1)
+	assert.Len(t, l2.Sinks(), 2)
+	assert.Equal(t, "[INFO] foo\n[INFO] bar\n", b1.String())
+	assert.Equal(t, "[INFO] bar\n", b2.String())
+}
+
+func TestMultiWithOutput(t *testing.T) {
+	var b1, b2 bytes.Buffer
+
+	l := log.NewMulti(log.Sink{Output: &b1, Format: log.HumanFormat}).WithOutput(&b2)
+	l.Print("foo")
+
+	assert.Equal(t, "", b1.String())
+	assert.Equal(t, "\033_klio_mode \"line\"\033\\\033_klio_log_level \"info\"\033\\\033_klio_tags []\033\\foo\033_klio_reset\033\\\n", b2.String())
+}
+
+func TestMultiOutput(t *testing.T) {
+	t.Run("pass bytes to all sinks without rendering", func(t *testing.T) {
+		var b1, b2 bytes.Buffer
+
+		l := log.NewMulti(
+			log.Sink{Output: &b1, Format: log.HumanFormat},
+			log.Sink{Output: &b2, Format: log.JSONFormat, Level: log.ErrorLevel},
+		)
+		line := "\033_klio_reset\033\\foo\n"
+		n, err := l.Output().Write([]byte(line))
+
+		assert.NoError(t, err)
+		assert.Equal(t, len(line), n)
+		assert.Equal(t, line, b1.String())
+		assert.Equal(t, line, b2.String())
+	})
+
+	t.Run("decorate lines once when used as output of another logger", func(t *testing.T) {
+		var b1, b2 bytes.Buffer
+
+		l := log.NewMulti(
+			log.Sink{Output: &b1, Format: log.HumanFormat},
+			log.Sink{Output: &b2, Format: log.JSONFormat},
+		)
+		l.WithOutput(l.Output()).Print("foo")
+
+		line := "\033_klio_mode \"line\"\033\\\033_klio_log_level \"info\"\033\\\033_klio_tags []\033\\foo\033_klio_reset\033\\\n"
+		assert.Equal(t, line, b1.String())
+		assert.Equal(t, line, b2.String())
+	})
+
+	t.Run("aggregate errors returned by sinks", func(t *testing.T) {
+		var b bytes.Buffer
+		err1 := errors.New("err1")
+		err2 := errors.New("err2")
+
+		l := log.NewMulti(
+			log.Sink{Output: failingWriter{err1}},
+			log.Sink{Output: &b},
+			log.Sink{Output: failingWriter{err2}},
+		)
+		n, err := l.Output().Write([]byte("foo\n"))
+
+		assert.Equal(t, 4, n)
+		assert.Equal(t, log.MultiError{err1, err2}, err)
+		assert.Equal(t, "foo\n", b.String())
+	})
+}
+
+func TestMultiNilOutput(t *testing.T) {
+	var b bytes.Buffer
+
+	l := log.NewMulti(log.Sink{Format: log.JSONFormat}, log.Sink{Output: &b, Format: log.HumanFormat})
+	l = l.WithSink(log.Sink{Format: log.HumanFormat})
+	l.Print("foo")
+
+	assert.Equal(t, []log.Sink{{Output: &b, Format: log.HumanFormat}}, l.Sinks())
+	assert.Equal(t, "[INFO] foo\n", b.String())
+}
+
+func TestMultiFormat(t *testing.T) {
+	var b1, b2 bytes.Buffer
+
+	l := log.NewMulti(
+		log.Sink{Output: &b1, Format: log.KlioFormat},
+		log.Sink{Output: &b2, Format: log.Format("unknown")},
+	)
+	l.Print("foo")
+
+	assert.Equal(t, "\033_klio_mode \"line\"\033\\\033_klio_log_level \"info\"\033\\\033_klio_tags []\033\\foo\033_klio_reset\033\\\n", b1.String())
+	assert.Equal(t, b1.String(), b2.String())
+}
+
+func TestMultiWriter(t *testing.T) {
+	t.Run("write lines to sinks in order", func(t *testing.T) {
+		var b1, b2 bytes.Buffer
+		var w io.Writer = log.NewMulti(
+			log.Sink{Output: &b1, Format: log.HumanFormat},
+			log.Sink{Output: &b2, Format: log.JSONFormat},
+		)
+
+		n, err := w.Write([]byte("foo\nbar"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 7, n)
+		assert.Equal(t, "[INFO] foo\n[INFO] bar\n", b1.String())
+		assert.Equal(
+			t,
+			"{\"level\":\"info\",\"mode\":\"line\",\"tags\":[],\"message\":\"foo\"}\n"+
+				"{\"level\":\"info\",\"mode\":\"line\",\"tags\":[],\"message\":\"bar\"}\n",
+			b2.String(),
+		)
+	})
+
+	t.Run("aggregate errors returned by sinks", func(t *testing.T) {
+		var b bytes.Buffer
+		err1 := errors.New("err1")
+		err2 := errors.New("err2")
+		var w io.Writer = log.NewMulti(
+			log.Sink{Output: failingWriter{err1}},
+			log.Sink{Output: &b, Format: log.HumanFormat},
+			log.Sink{Output: failingWriter{err2}},
+		)
+
+		n, err := w.Write([]byte("foo\nbar"))
+
+		assert.Equal(t, 7, n)
+		assert.Equal(t, log.MultiError{err1, err2, err1, err2}, err)
+		assert.EqualError(t, err, "err1; err2; err1; err2")
+		assert.ErrorIs(t, err, err1)
+		assert.ErrorIs(t, err, err2)
+		assert.Equal(t, "[INFO] foo\n[INFO] bar\n", b.String())
+	})
+
+	t.Run("find typed errors returned by sinks", func(t *testing.T) {
+		var target sinkError
+		var w io.Writer = log.NewMulti(
+			log.Sink{Output: failingWriter{errors.New("err1")}},
+			log.Sink{Output: failingWriter{sinkError("err2")}},
+		)
+
+		_, err := w.Write([]byte("foo"))
+
+		assert.ErrorAs(t, err, &target)
+		assert.Equal(t, sinkError("err2"), target)
+	})
+}